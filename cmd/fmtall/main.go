@@ -0,0 +1,144 @@
+// Command fmtall dispatches every file under the given paths to the
+// devtools formatter for its language: mvdan.cc/gofumpt for Go, yamlfmt for
+// YAML, markdownfmt for Markdown, and shfmt-ec (the same .editorconfig-aware
+// pipeline shfmt-ec and shcheck use) for shell scripts. Extension-less
+// scripts are classified by their shebang interpreter, the same way shfmt
+// itself guesses a file's shell dialect.
+//
+// fmtall is the single entrypoint meant to replace ad hoc pip/npm/apt
+// formatter installs in the build environment: every formatter it drives is
+// pinned as a `tool` directive in this module's go.mod.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"mvdan.cc/sh/v3/fileutil"
+)
+
+var (
+	write = flag.Bool("w", false, "write formatted output back to each file")
+	diff  = flag.Bool("d", false, "print a diff instead of writing")
+	list  = flag.Bool("l", false, "list files whose formatting differs")
+)
+
+// formatter describes the go tool that formats one language.
+type formatter struct {
+	tool string
+}
+
+var formatters = map[string]formatter{
+	"go":       {tool: "mvdan.cc/gofumpt"},
+	"yaml":     {tool: "github.com/google/yamlfmt/cmd/yamlfmt"},
+	"markdown": {tool: "github.com/shurcooL/markdownfmt"},
+	"shell":    {tool: "searxng.org/devtools/cmd/shfmt-ec"},
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: go tool fmtall [-w] [-d] [-l] path...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	status := 0
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			lang := classify(path)
+			if lang == "" {
+				return nil
+			}
+			if err := dispatch(lang, path); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+				status = 1
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(status)
+}
+
+// classify returns the language key under which path should be formatted,
+// or "" if fmtall has no formatter for it.
+func classify(path string) string {
+	switch filepath.Ext(path) {
+	case ".go":
+		return "go"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".md", ".markdown":
+		return "markdown"
+	case ".sh", ".bash", ".ksh", ".mksh":
+		return "shell"
+	case "":
+		return classifyByShebang(path)
+	}
+	return ""
+}
+
+// classifyByShebang reads a file's shebang and reports "shell" if
+// mvdan.cc/sh/v3/fileutil recognizes its interpreter, the same heuristic
+// shfmt itself uses to guess a file's shell dialect, or "" otherwise.
+func classifyByShebang(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32)
+	n, err := io.ReadAtLeast(f, buf, len("#!/bin/sh\n"))
+	if err != nil {
+		return ""
+	}
+	if fileutil.Shebang(buf[:n]) == "" {
+		return ""
+	}
+	return "shell"
+}
+
+func dispatch(lang, path string) error {
+	f := formatters[lang]
+
+	args := []string{"tool", f.tool}
+	switch {
+	case *write:
+		args = append(args, "-w")
+	case *diff:
+		args = append(args, "-d")
+	case *list:
+		args = append(args, "-l")
+	}
+	args = append(args, path)
+
+	cmd := exec.Command("go", args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if out.Len() > 0 {
+		fmt.Print(out.String())
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyByExtension(t *testing.T) {
+	cases := map[string]string{
+		"main.go":     "go",
+		"config.yaml": "yaml",
+		"config.yml":  "yaml",
+		"README.md":   "markdown",
+		"notes.txt":   "",
+		"build.sh":    "shell",
+		"run.bash":    "shell",
+	}
+	for name, want := range cases {
+		if got := classify(filepath.Join(t.TempDir(), name)); got != want {
+			t.Errorf("classify(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestClassifyByShebang(t *testing.T) {
+	cases := []struct {
+		name, shebang, want string
+	}{
+		{"bash-direct", "#!/bin/bash\n", "shell"},
+		{"sh-direct", "#!/bin/sh\n", "shell"},
+		{"env-bash", "#!/usr/bin/env bash\n", "shell"},
+		{"python", "#!/usr/bin/env python3\n", ""},
+		{"no-shebang", "just a file\n", ""},
+	}
+	for _, tc := range cases {
+		path := filepath.Join(t.TempDir(), "script")
+		if err := os.WriteFile(path, []byte(tc.shebang), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := classify(path); got != tc.want {
+			t.Errorf("%s: classify() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Command shcheck is a go tool wrapper around mvdan.cc/sh/v3 that fails a CI
+// build when shell scripts are not already shfmt-clean. It resolves each
+// file's style the same way shfmt-ec does (see
+// searxng.org/devtools/internal/ecopts), so the two commands never disagree
+// about what "formatted" means, and never shells out to a separately
+// installed shfmt binary.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"searxng.org/devtools/internal/ecopts"
+	"searxng.org/devtools/internal/shfmt"
+)
+
+type globList []string
+
+func (g *globList) String() string { return fmt.Sprint([]string(*g)) }
+
+func (g *globList) Set(v string) error {
+	*g = append(*g, v)
+	return nil
+}
+
+var (
+	excludes globList
+	jobs     = flag.Int("jobs", runtime.GOMAXPROCS(0), "number of files to format concurrently")
+)
+
+func main() {
+	flag.Var(&excludes, "exclude", "glob pattern to skip (repeatable)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: go tool shcheck [-exclude pattern] [-jobs N] path...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !shfmt.IsShellFile(p) || excluded(p) {
+				return nil
+			}
+			files = append(files, p)
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	diffs := make([]*bytes.Buffer, len(files))
+	errs := make([]error, len(files))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, *jobs)
+	for i, path := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			diffs[i], errs[i] = checkFile(path)
+		}(i, path)
+	}
+	wg.Wait()
+
+	dirty := false
+	for i, path := range files {
+		if err := errs[i]; err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			dirty = true
+			continue
+		}
+		if d := diffs[i]; d != nil {
+			dirty = true
+			fmt.Print(d.String())
+		}
+	}
+	if dirty {
+		os.Exit(1)
+	}
+}
+
+func excluded(path string) bool {
+	for _, pat := range excludes {
+		if ok, _ := filepath.Match(pat, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFile returns a unified diff between path's contents and its
+// shfmt-formatted form, or nil if the file is already clean.
+func checkFile(path string) (*bytes.Buffer, error) {
+	opts, err := ecopts.Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := shfmt.Format(path, src, opts)
+	if err != nil {
+		return nil, err
+	}
+	return shfmt.Diff(path, src, want)
+}
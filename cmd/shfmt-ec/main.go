@@ -0,0 +1,106 @@
+// Command shfmt-ec formats shell scripts with mvdan.cc/sh/v3, choosing each
+// file's formatting style from the .editorconfig files above it instead of
+// from command-line flags. See searxng.org/devtools/internal/ecopts for how
+// that style is resolved.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"searxng.org/devtools/internal/ecopts"
+	"searxng.org/devtools/internal/shfmt"
+)
+
+var (
+	write    = flag.Bool("w", false, "write result to file instead of stdout")
+	diffFlag = flag.Bool("d", false, "print a diff instead of writing")
+	list     = flag.Bool("l", false, "list files whose formatting differs")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: shfmt-ec [-w] [-d] [-l] path...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	changed := false
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// A file named on the command line is formatted whatever its
+			// extension; files found inside a directory must look like shell.
+			if d.IsDir() || (p != path && !shfmt.IsShellFile(p)) {
+				return nil
+			}
+			fileChanged, err := formatFile(p)
+			if err != nil {
+				return err
+			}
+			changed = changed || fileChanged
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if *list && changed {
+		os.Exit(1)
+	}
+}
+
+// formatFile rewrites, diffs, lists or prints path according to the
+// -w/-d/-l flags and reports whether its formatting changed.
+func formatFile(path string) (bool, error) {
+	opts, err := ecopts.Resolve(path)
+	if err != nil {
+		return false, err
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	want, err := shfmt.Format(path, src, opts)
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(src, want) {
+		return false, nil
+	}
+
+	switch {
+	case *write:
+		if err := os.WriteFile(path, want, 0o644); err != nil {
+			return false, err
+		}
+	case *diffFlag:
+		d, err := shfmt.Diff(path, src, want)
+		if err != nil {
+			return false, err
+		}
+		fmt.Print(d.String())
+	case *list:
+		fmt.Println(path)
+	default:
+		if _, err := io.Copy(os.Stdout, bytes.NewReader(want)); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
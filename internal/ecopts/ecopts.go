@@ -0,0 +1,71 @@
+// Package ecopts resolves mvdan.cc/sh/v3 formatting options from
+// .editorconfig files.
+//
+// It understands these properties that shfmt itself recognizes when asked to
+// follow EditorConfig: indent_style, indent_size, binary_next_line,
+// switch_case_indent, space_redirects, keep_padding and function_next_line.
+// Other shfmt properties, such as shell_variant, are not read.
+// Resolving those properties for a given file is delegated to
+// mvdan.cc/editorconfig's own Find, which already walks up from the file's
+// directory merging every .editorconfig section that matches, stopping
+// once a file declares itself root.
+package ecopts
+
+import (
+	"mvdan.cc/editorconfig"
+)
+
+// Options mirrors the subset of mvdan.cc/sh/v3/syntax.Printer options that
+// can be driven from EditorConfig.
+type Options struct {
+	Indent           uint
+	BinaryNextLine   bool
+	SwitchCaseIndent bool
+	SpaceRedirects   bool
+	KeepPadding      bool
+	FunctionNextLine bool
+}
+
+// Default is used for any property no .editorconfig section sets.
+var Default = Options{Indent: 0}
+
+// Resolve returns the formatting options that apply to path, as determined
+// by the .editorconfig files above it.
+func Resolve(path string) (Options, error) {
+	section, err := editorconfig.Find(path, nil)
+	if err != nil {
+		return Default, err
+	}
+
+	opts := Default
+	apply(&opts, section)
+	return opts, nil
+}
+
+// apply mirrors mvdan.cc/sh/v3/cmd/shfmt's own propsOptions: indent_size is
+// only consulted when indent_style is "space", and defaults to 8 in that
+// case; any other indent_style (including unset) means tabs.
+func apply(opts *Options, section editorconfig.Section) {
+	opts.Indent = 0
+	if section.Get("indent_style") == "space" {
+		opts.Indent = 8
+		if n := section.IndentSize(); n > 0 {
+			opts.Indent = uint(n)
+		}
+	}
+	if v := section.Get("binary_next_line"); v != "" {
+		opts.BinaryNextLine = v == "true"
+	}
+	if v := section.Get("switch_case_indent"); v != "" {
+		opts.SwitchCaseIndent = v == "true"
+	}
+	if v := section.Get("space_redirects"); v != "" {
+		opts.SpaceRedirects = v == "true"
+	}
+	if v := section.Get("keep_padding"); v != "" {
+		opts.KeepPadding = v == "true"
+	}
+	if v := section.Get("function_next_line"); v != "" {
+		opts.FunctionNextLine = v == "true"
+	}
+}
@@ -0,0 +1,122 @@
+package ecopts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveMergesNestedEditorConfigs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".editorconfig"), `root = true
+
+[*.sh]
+indent_style = space
+indent_size = 4
+binary_next_line = true
+`)
+	writeFile(t, filepath.Join(dir, "scripts", ".editorconfig"), `[*.sh]
+indent_size = 2
+`)
+	script := filepath.Join(dir, "scripts", "build.sh")
+	writeFile(t, script, "#!/bin/sh\necho hi\n")
+
+	opts, err := Resolve(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Indent != 2 {
+		t.Errorf("Indent = %d, want 2 (nearer directory should win)", opts.Indent)
+	}
+	if !opts.BinaryNextLine {
+		t.Error("BinaryNextLine = false, want true (inherited from root .editorconfig)")
+	}
+}
+
+func TestResolveStopsAtRoot(t *testing.T) {
+	outer := t.TempDir()
+	writeFile(t, filepath.Join(outer, ".editorconfig"), `[*.sh]
+indent_style = space
+indent_size = 8
+`)
+
+	inner := filepath.Join(outer, "inner")
+	writeFile(t, filepath.Join(inner, ".editorconfig"), `root = true
+
+[*.sh]
+indent_style = space
+indent_size = 2
+`)
+	script := filepath.Join(inner, "run.sh")
+	writeFile(t, script, "#!/bin/sh\necho hi\n")
+
+	opts, err := Resolve(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Indent != 2 {
+		t.Errorf("Indent = %d, want 2 (outer .editorconfig must not apply past root = true)", opts.Indent)
+	}
+}
+
+func TestResolveDefaultsWithoutEditorConfig(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "run.sh")
+	writeFile(t, script, "#!/bin/sh\necho hi\n")
+
+	opts, err := Resolve(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts != Default {
+		t.Errorf("opts = %+v, want Default %+v", opts, Default)
+	}
+}
+
+func TestResolveIndentStyleSpaceWithoutSizeDefaultsToEight(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".editorconfig"), `root = true
+
+[*.sh]
+indent_style = space
+`)
+	script := filepath.Join(dir, "run.sh")
+	writeFile(t, script, "#!/bin/sh\necho hi\n")
+
+	opts, err := Resolve(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Indent != 8 {
+		t.Errorf("Indent = %d, want 8 (shfmt's own default for indent_style=space)", opts.Indent)
+	}
+}
+
+func TestResolveIndentSizeWithoutIndentStyleIsIgnored(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".editorconfig"), `root = true
+
+[*.sh]
+indent_size = 4
+`)
+	script := filepath.Join(dir, "run.sh")
+	writeFile(t, script, "#!/bin/sh\necho hi\n")
+
+	opts, err := Resolve(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Indent != 0 {
+		t.Errorf("Indent = %d, want 0 (indent_size is only honored when indent_style = space)", opts.Indent)
+	}
+}
@@ -0,0 +1,63 @@
+// Package shfmt centralizes the mvdan.cc/sh/v3 parse-then-print pipeline
+// shared by the devtools commands, so every tool formats a given file the
+// same way.
+package shfmt
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/pkg/diff"
+	"mvdan.cc/sh/v3/syntax"
+
+	"searxng.org/devtools/internal/ecopts"
+)
+
+// IsShellFile reports whether path's extension marks it as a shell script.
+// shcheck formats only such files; shfmt-ec uses it to pick files found
+// while walking a directory.
+func IsShellFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".sh", ".bash", ".ksh", ".mksh":
+		return true
+	}
+	return false
+}
+
+// Format parses src under name and returns its canonical formatting under
+// opts. name is only used for error messages.
+func Format(name string, src []byte, opts ecopts.Options) ([]byte, error) {
+	f, err := syntax.NewParser(syntax.KeepComments(true)).Parse(bytes.NewReader(src), name)
+	if err != nil {
+		return nil, err
+	}
+
+	printer := syntax.NewPrinter(
+		syntax.Indent(opts.Indent),
+		syntax.BinaryNextLine(opts.BinaryNextLine),
+		syntax.SwitchCaseIndent(opts.SwitchCaseIndent),
+		syntax.SpaceRedirects(opts.SpaceRedirects),
+		syntax.KeepPadding(opts.KeepPadding),
+		syntax.FunctionNextLine(opts.FunctionNextLine),
+	)
+
+	var buf bytes.Buffer
+	if err := printer.Print(&buf, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Diff returns a unified diff between src and want, the formatted form of
+// the file at path, or nil if they're equal.
+func Diff(path string, src, want []byte) (*bytes.Buffer, error) {
+	if bytes.Equal(src, want) {
+		return nil, nil
+	}
+
+	var out bytes.Buffer
+	if err := diff.Text(path+" (on disk)", path+" (shfmt)", src, want, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}